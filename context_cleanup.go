@@ -0,0 +1,73 @@
+package kong
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// cleanupMu guards cleanupFuncs.
+var cleanupMu sync.Mutex
+
+// cleanupFuncs holds, per *Context, the callbacks registered via OnDone
+// that haven't run yet. It is keyed by the Context's address converted to
+// a uintptr rather than by *Context itself: a uintptr is an opaque integer
+// to the garbage collector, so holding one in this package-level map does
+// not keep the Context reachable. Keying by *Context directly would pin
+// every Context ever passed through OnDone forever, since a live reference
+// from a reachable map is itself a strong reference and the finalizer
+// below would never have a chance to run.
+var cleanupFuncs = map[uintptr][]func(){}
+
+// Key returns an opaque, comparable identifier for k, suitable as a key in
+// a long-lived map (for example a Resolver's per-Context cache) without
+// that map itself keeping k alive. Unlike k, a Key value is not a pointer
+// as far as the garbage collector is concerned, so holding one does not
+// count as a reference to k: only pair it with an OnDone callback that
+// evicts the entry, or the map will grow for every distinct Context that
+// was ever used, collected or not. k may be nil (a Resolver exercised
+// directly in a test, outside of a real parse), in which case Key returns 0.
+func (k *Context) Key() uintptr {
+	if k == nil {
+		return 0
+	}
+	return uintptr(unsafe.Pointer(k))
+}
+
+// OnDone registers fn to run once ctx is garbage collected. Unlike a bare
+// runtime.SetFinalizer, which keeps only the most recently registered
+// finalizer for a given object, every fn registered through OnDone runs:
+// OnDone sets at most one finalizer per Context and accumulates callbacks
+// behind it. That composability is the point: independent Resolvers -- and
+// a Chain wrapping several of them -- can each register their own eviction
+// for a per-Context cache without knowing about each other, and without one
+// caller's cleanup clobbering another's the way a second SetFinalizer call
+// would.
+//
+// k may be nil (a Resolver exercised directly in a test, outside of a real
+// parse): runtime.SetFinalizer on a nil pointer is a fatal, unrecoverable
+// runtime error rather than a panic, so OnDone is a no-op in that case
+// rather than risking it.
+func (k *Context) OnDone(fn func()) {
+	if k == nil {
+		return
+	}
+	addr := uintptr(unsafe.Pointer(k))
+	cleanupMu.Lock()
+	fns, registered := cleanupFuncs[addr]
+	cleanupFuncs[addr] = append(fns, fn)
+	cleanupMu.Unlock()
+	if registered {
+		return
+	}
+	runtime.SetFinalizer(k, func(c *Context) {
+		a := uintptr(unsafe.Pointer(c))
+		cleanupMu.Lock()
+		fns := cleanupFuncs[a]
+		delete(cleanupFuncs, a)
+		cleanupMu.Unlock()
+		for _, fn := range fns {
+			fn()
+		}
+	})
+}
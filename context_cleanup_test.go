@@ -0,0 +1,54 @@
+package kong
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// TestOnDoneRunsAfterGarbageCollection forces a GC cycle and asserts that a
+// callback registered via OnDone actually fires, and that the bookkeeping
+// map used to track it is cleared afterwards. This guards against OnDone
+// silently pinning every Context it ever sees instead of evicting them.
+func TestOnDoneRunsAfterGarbageCollection(t *testing.T) {
+	done := make(chan struct{})
+	var key uintptr
+	func() {
+		ctx := &Context{}
+		key = ctx.Key()
+		ctx.OnDone(func() { close(done) })
+	}()
+
+	ran := false
+	for i := 0; i < 50 && !ran; i++ {
+		runtime.GC()
+		select {
+		case <-done:
+			ran = true
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+	if !ran {
+		t.Fatal("OnDone callback never ran after repeated GC cycles")
+	}
+
+	cleanupMu.Lock()
+	_, tracked := cleanupFuncs[key]
+	cleanupMu.Unlock()
+	if tracked {
+		t.Error("cleanupFuncs still holds an entry for a collected Context")
+	}
+}
+
+// TestOnDoneNilContextIsNoOp guards against a regression to
+// runtime.SetFinalizer((*Context)(nil), ...), which is a fatal,
+// unrecoverable runtime error rather than a panic: a Resolver exercised
+// directly against a nil *Context, as resolver_toml_test.go does, must not
+// crash the test binary.
+func TestOnDoneNilContextIsNoOp(t *testing.T) {
+	var ctx *Context
+	ctx.OnDone(func() { t.Error("OnDone callback must never run for a nil Context") })
+	if ctx.Key() != 0 {
+		t.Errorf("Key() = %d, want 0 for a nil Context", ctx.Key())
+	}
+}
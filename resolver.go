@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 )
 
 // A Resolver resolves a Flag value from an external source.
@@ -29,6 +30,22 @@ func (r ResolverFunc) Resolve(context *Context, parent *Path, flag *Flag) (any,
 }
 func (r ResolverFunc) Validate(app *Application) error { return nil } //nolint: revive
 
+// NamedResolver is implemented by Resolvers that can identify themselves.
+// Chain uses this to report, via the Context's ResolutionTrace, which
+// resolver supplied a flag's final value.
+type NamedResolver interface {
+	Resolver
+	Name() string
+}
+
+// namedResolver adapts a ResolverFunc into a NamedResolver.
+type namedResolver struct {
+	name string
+	ResolverFunc
+}
+
+func (r namedResolver) Name() string { return r.name } //nolint: revive
+
 // JSON returns a Resolver that retrieves values from a JSON source.
 //
 // Flag names are used as JSON keys indirectly, by tring snake_case and camelCase variants.
@@ -38,7 +55,15 @@ func JSON(r io.Reader) (Resolver, error) {
 	if err != nil {
 		return nil, err
 	}
-	var f ResolverFunc = func(context *Context, parent *Path, flag *Flag) (any, error) {
+	return namedResolver{name: "json", ResolverFunc: mapResolver(values)}, nil
+}
+
+// mapResolver returns a Resolver that looks up flag values in a decoded
+// hierarchical map, trying the raw name, its snake_case variant, and finally
+// descending through dotted sub-keys. It is shared by resolvers backed by a
+// map[string]any, such as JSON and YAML.
+func mapResolver(values map[string]any) ResolverFunc {
+	return func(context *Context, parent *Path, flag *Flag) (any, error) {
 		name := strings.ReplaceAll(flag.Name, "-", "_")
 		snakeCaseName := snakeCase(flag.Name)
 		raw, ok := values[name]
@@ -60,8 +85,6 @@ func JSON(r io.Reader) (Resolver, error) {
 		}
 		return raw, nil
 	}
-
-	return f, nil
 }
 
 func snakeCase(name string) string {
@@ -75,14 +98,13 @@ func EnvResolver() Resolver {
 	// However, environment variable annotations can also apply to arguments,
 	// as demonstrated in this test:
 	// https://github.com/alecthomas/kong/blob/v1.6.0/kong_test.go#L1226-L1244
-	// To handle this, we ensure that arguments are resolved as well.
-	// Since the resolution only needs to happen once, we use this boolean
-	// to track whether the resolution process has already been performed.
-	argsResolved := false
-	return ResolverFunc(func(context *Context, parent *Path, flag *Flag) (interface{}, error) {
-		if !argsResolved {
-			resolveArgs(context.Path)
-			argsResolved = true
+	// To handle this, we ensure that arguments are resolved as well, via
+	// resolveArgsOnce so that registering EnvResolver alongside DotEnv (or
+	// any other env-backed Resolver) against the same Context doesn't apply
+	// the same env value to a positional twice. See resolveArgsOnce.
+	return namedResolver{name: "env", ResolverFunc: func(context *Context, parent *Path, flag *Flag) (interface{}, error) {
+		if err := resolveArgsOnce(context, os.LookupEnv); err != nil {
+			return nil, err
 		}
 		for _, env := range flag.Tag.Envs {
 			envar, ok := os.LookupEnv(env)
@@ -92,37 +114,107 @@ func EnvResolver() Resolver {
 			}
 		}
 		return nil, nil
-	})
+	}}
 }
 
-func resolveArgs(paths []*Path) error {
+var (
+	argsResolvedMu sync.Mutex
+	// argsResolvedValues tracks, per Context (keyed by Context.Key rather
+	// than *Context itself, for the same reason as cleanupFuncs and the aws
+	// resolvers' cache), which positional *Values have already had an env
+	// value applied to them. The set is shared across every env-backed
+	// Resolver rather than kept as a closure-local flag on each one:
+	// EnvResolver and DotEnv are commonly registered together (DotEnv is
+	// designed to layer on top of EnvResolver), and each needs a chance to
+	// fill whatever positionals the earlier resolver's lookup didn't find a
+	// value for -- a single all-or-nothing "has anyone resolved args for
+	// this Context yet" gate would let the first resolver's lookup claim
+	// every positional and silently skip the rest.
+	argsResolvedValues = map[uintptr]map[*Value]bool{}
+)
+
+// resolveArgsOnce calls resolveArgs(context.Path, lookup, resolved) for
+// context, sharing its resolved set across every call for the same Context
+// regardless of which Resolver calls it: once lookup has supplied a value
+// for a positional, later callers (i.e. later Resolvers in the same
+// kong.Resolvers registration) skip it, but a positional lookup doesn't
+// find a value for is left for the next caller's lookup to try. See
+// argsResolvedValues.
+func resolveArgsOnce(context *Context, lookup func(string) (string, bool)) error {
+	if context == nil {
+		return nil
+	}
+	key := context.Key()
+	argsResolvedMu.Lock()
+	resolved, ok := argsResolvedValues[key]
+	if !ok {
+		resolved = map[*Value]bool{}
+		argsResolvedValues[key] = resolved
+		context.OnDone(func() {
+			argsResolvedMu.Lock()
+			delete(argsResolvedValues, key)
+			argsResolvedMu.Unlock()
+		})
+	}
+	argsResolvedMu.Unlock()
+	return resolveArgs(context.Path, lookup, resolved)
+}
+
+// resolveArgs applies lookup to the env tags of every positional argument in
+// paths, so that `env:"FOO"` on an argument is populated the same way it is
+// on a flag, skipping any Value already marked resolved and marking as
+// resolved any it fills. It is shared by EnvResolver and DotEnv, parameterised
+// by lookup so each can supply its own source of env values.
+func resolveArgs(paths []*Path, lookup func(string) (string, bool), resolved map[*Value]bool) error {
 	for _, path := range paths {
-		if path.Command == nil {
+		// path.Node covers every kind of Path element that can carry
+		// positionals, not just path.Command: a positional declared
+		// directly on the root Application, with no enclosing
+		// subcommand, shows up as path.App instead.
+		node := path.Node()
+		if node == nil {
 			continue
 		}
-		for _, positional := range path.Command.Positional {
-			if positional.Tag == nil {
+		for _, positional := range node.Positional {
+			if positional.Tag == nil || resolved[positional] {
 				continue
 			}
-			visitValue(positional)
+			hit, err := visitValue(positional, lookup)
+			if err != nil {
+				return err
+			}
+			if hit {
+				resolved[positional] = true
+			}
 		}
-		if path.Command.Argument != nil {
-			visitValue(path.Command.Argument)
+		if node.Argument != nil && !resolved[node.Argument] {
+			hit, err := visitValue(node.Argument, lookup)
+			if err != nil {
+				return err
+			}
+			if hit {
+				resolved[node.Argument] = true
+			}
 		}
 	}
 	return nil
 }
 
-func visitValue(value *Value) error {
+// visitValue applies lookup to value's env tags, parsing in the first
+// matching value it finds for each, and reports whether lookup found a
+// value for at least one of them.
+func visitValue(value *Value, lookup func(string) (string, bool)) (bool, error) {
+	hit := false
 	for _, env := range value.Tag.Envs {
-		envar, ok := os.LookupEnv(env)
+		envar, ok := lookup(env)
 		if !ok {
 			continue
 		}
+		hit = true
 		token := Token{Type: FlagValueToken, Value: envar}
 		if err := value.Parse(ScanFromTokens(token), value.Target); err != nil {
-			return fmt.Errorf("%s (from envar %s=%q)", err, env, envar)
+			return hit, fmt.Errorf("%s (from envar %s=%q)", err, env, envar)
 		}
 	}
-	return nil
+	return hit, nil
 }
@@ -0,0 +1,138 @@
+package kong
+
+import (
+	"fmt"
+	"sync"
+)
+
+// chainResolver tries each wrapped Resolver in turn, returning the first
+// non-nil value.
+type chainResolver struct {
+	resolvers []Resolver
+}
+
+// Chain returns a Resolver that walks resolvers in order and returns the
+// value from the first one that resolves a non-nil value for a flag. This
+// lets a config file, environment, and secret store be layered with
+// explicit precedence instead of relying on the order flags happen to be
+// registered with kong.Resolvers.
+//
+// If the first resolved value is a map[string]any, later resolvers are
+// still consulted for that flag, and any map values they return are merged
+// in: keys already set by an earlier (higher-precedence) resolver are kept,
+// keys only present in a later resolver are added. Non-map values always
+// short-circuit on the first hit.
+//
+// If a wrapped Resolver implements NamedResolver, the winning resolver's
+// name is recorded in the Context's ResolutionTrace. For a merged map, that
+// is the first resolver that contributed to it.
+func Chain(resolvers ...Resolver) Resolver {
+	return &chainResolver{resolvers: resolvers}
+}
+
+func (c *chainResolver) Validate(app *Application) error {
+	for _, resolver := range c.resolvers {
+		if err := resolver.Validate(app); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *chainResolver) Resolve(context *Context, parent *Path, flag *Flag) (any, error) {
+	var merged map[string]any
+	var winner Resolver
+
+	for _, resolver := range c.resolvers {
+		value, err := resolver.Resolve(context, parent, flag)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		m, ok := value.(map[string]any)
+		if !ok {
+			// A non-map value always wins outright and short-circuits the
+			// chain, matching the behaviour before map merging existed.
+			recordTrace(context, flag.Name, resolverName(resolver), value)
+			return value, nil
+		}
+		if merged == nil {
+			winner = resolver
+			merged = map[string]any{}
+		}
+		for k, v := range m {
+			if _, exists := merged[k]; !exists {
+				merged[k] = v
+			}
+		}
+	}
+	if merged == nil {
+		return nil, nil
+	}
+	recordTrace(context, flag.Name, resolverName(winner), merged)
+	return merged, nil
+}
+
+func resolverName(r Resolver) string {
+	if named, ok := r.(NamedResolver); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T", r)
+}
+
+// TraceEntry records which Resolver supplied a flag's final value, and what
+// that value was, during a single parse.
+type TraceEntry struct {
+	Flag     string
+	Resolver string
+	Value    any
+}
+
+var (
+	traceMu sync.Mutex
+	// traces is keyed by Context.Key rather than *Context itself: the
+	// latter would be a strong reference from this package-level map,
+	// pinning every Context ever traced for the life of the process and
+	// defeating the OnDone eviction below.
+	traces = map[uintptr][]TraceEntry{}
+)
+
+func recordTrace(context *Context, flagName, resolver string, value any) {
+	key := context.Key()
+	traceMu.Lock()
+	defer traceMu.Unlock()
+	if _, ok := traces[key]; !ok {
+		// Evict this trace once the Context is garbage collected. See
+		// Context.OnDone's doc comment for why OnDone, not a bare
+		// runtime.SetFinalizer, is what makes this safe to do alongside
+		// other per-Context eviction, such as the aws resolvers' cache.
+		context.OnDone(func() {
+			traceMu.Lock()
+			delete(traces, key)
+			traceMu.Unlock()
+		})
+	}
+	traces[key] = append(traces[key], TraceEntry{Flag: flagName, Resolver: resolver, Value: value})
+}
+
+// ResolutionTrace returns, in resolution order, which Resolver supplied the
+// value for each flag resolved by a Chain during this parse. Flags resolved
+// outside of a Chain, or left to their default, are not recorded.
+func (k *Context) ResolutionTrace() []TraceEntry {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+	return append([]TraceEntry(nil), traces[k.Key()]...)
+}
+
+// PrintResolutionTrace writes ctx's ResolutionTrace to Kong's Stdout, one
+// line per resolved flag. It does not register a flag itself: wire it up to
+// your own `--debug-config` bool flag's AfterApply (or call it manually
+// after Parse) to get a `--debug-config`-style diagnostic of which resolver
+// supplied each flag's value.
+func (k *Kong) PrintResolutionTrace(ctx *Context) {
+	for _, entry := range ctx.ResolutionTrace() {
+		fmt.Fprintf(k.Stdout, "%s = %v (from %s)\n", entry.Flag, entry.Value, entry.Resolver)
+	}
+}
@@ -0,0 +1,115 @@
+package kong_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kong"
+)
+
+func TestChainReturnsFirstHit(t *testing.T) {
+	first, err := kong.JSON(strings.NewReader(`{"name": "from-first"}`))
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	second, err := kong.JSON(strings.NewReader(`{"name": "from-second", "other": "from-second"}`))
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+
+	var cli struct {
+		Name  string
+		Other string
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(kong.Chain(first, second)))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if cli.Name != "from-first" {
+		t.Errorf("Name = %q, want %q (first resolver should win)", cli.Name, "from-first")
+	}
+	if cli.Other != "from-second" {
+		t.Errorf("Other = %q, want %q (should fall through to second resolver)", cli.Other, "from-second")
+	}
+}
+
+func TestChainMergesMapValuedFlags(t *testing.T) {
+	first, err := kong.JSON(strings.NewReader(`{"labels": {"a": "from-first", "shared": "from-first"}}`))
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	second, err := kong.JSON(strings.NewReader(`{"labels": {"b": "from-second", "shared": "from-second"}}`))
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+
+	var cli struct {
+		Labels map[string]string
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(kong.Chain(first, second)))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	ctx, err := parser.Parse(nil)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	want := map[string]string{"a": "from-first", "b": "from-second", "shared": "from-first"}
+	if len(cli.Labels) != len(want) {
+		t.Fatalf("Labels = %+v, want %+v", cli.Labels, want)
+	}
+	for k, v := range want {
+		if cli.Labels[k] != v {
+			t.Errorf("Labels[%q] = %q, want %q", k, cli.Labels[k], v)
+		}
+	}
+
+	trace := ctx.ResolutionTrace()
+	if len(trace) != 1 || trace[0].Resolver != "json" {
+		t.Fatalf("trace = %+v, want a single json entry for the merged map", trace)
+	}
+}
+
+func TestChainRecordsResolutionTrace(t *testing.T) {
+	first, err := kong.JSON(strings.NewReader(`{"name": "from-first"}`))
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	second, err := kong.JSON(strings.NewReader(`{"other": "from-second"}`))
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+
+	var cli struct {
+		Name  string
+		Other string
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(kong.Chain(first, second)))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	ctx, err := parser.Parse(nil)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	trace := ctx.ResolutionTrace()
+	if len(trace) != 2 {
+		t.Fatalf("len(trace) = %d, want 2: %+v", len(trace), trace)
+	}
+	byFlag := map[string]kong.TraceEntry{}
+	for _, entry := range trace {
+		byFlag[entry.Flag] = entry
+	}
+	if entry := byFlag["name"]; entry.Resolver != "json" || entry.Value != "from-first" {
+		t.Errorf("trace[name] = %+v, want Resolver=json Value=from-first", entry)
+	}
+	if entry := byFlag["other"]; entry.Resolver != "json" || entry.Value != "from-second" {
+		t.Errorf("trace[other] = %+v, want Resolver=json Value=from-second", entry)
+	}
+}
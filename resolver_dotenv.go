@@ -0,0 +1,57 @@
+package kong
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/joho/godotenv"
+)
+
+// DotEnv returns a Resolver that resolves flag and argument values from one
+// or more dotenv files, using the same `env` tag matching as EnvResolver.
+//
+// Each file is parsed with godotenv semantics (`KEY=value`, quoted values,
+// `#` comments, and an optional `export` prefix), but the values are never
+// written to os.Environ; they are only consulted by this Resolver. Real
+// environment variables always take precedence, followed by paths in the
+// order given.
+//
+// A positional argument's "env" tag is filled the same way, but only for
+// an optional positional. kong decides whether a *required* positional was
+// supplied by checking os.LookupEnv directly (see checkMissingPositionals
+// in context.go), before any Resolver gets a chance to run, so a
+// dotenv-only value can never satisfy a required positional.
+func DotEnv(paths ...string) (Resolver, error) {
+	envs := make([]map[string]string, len(paths))
+	for i, path := range paths {
+		env, err := godotenv.Read(path)
+		if err != nil {
+			return nil, fmt.Errorf("dotenv: %w", err)
+		}
+		envs[i] = env
+	}
+
+	lookup := func(key string) (string, bool) {
+		if value, ok := os.LookupEnv(key); ok {
+			return value, true
+		}
+		for _, env := range envs {
+			if value, ok := env[key]; ok {
+				return value, true
+			}
+		}
+		return "", false
+	}
+
+	return namedResolver{name: "dotenv", ResolverFunc: func(context *Context, parent *Path, flag *Flag) (any, error) {
+		if err := resolveArgsOnce(context, lookup); err != nil {
+			return nil, err
+		}
+		for _, env := range flag.Tag.Envs {
+			if value, ok := lookup(env); ok {
+				return value, nil
+			}
+		}
+		return nil, nil
+	}}, nil
+}
@@ -0,0 +1,144 @@
+package kong_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kong"
+)
+
+func writeDotEnv(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+	return path
+}
+
+func TestDotEnvPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	first := writeDotEnv(t, dir, "first.env", "FOO=from-first\nBAR=from-first\n")
+	second := writeDotEnv(t, dir, "second.env", "FOO=from-second\nBAZ=from-second\n")
+
+	resolver, err := kong.DotEnv(first, second)
+	if err != nil {
+		t.Fatalf("DotEnv: %v", err)
+	}
+
+	os.Setenv("BAR", "from-real-env")
+	t.Cleanup(func() { os.Unsetenv("BAR") })
+
+	var cli struct {
+		Foo string `env:"FOO"`
+		Bar string `env:"BAR"`
+		Baz string `env:"BAZ"`
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(resolver))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	// Real environment wins over every dotenv file.
+	if cli.Bar != "from-real-env" {
+		t.Errorf("Bar = %q, want %q", cli.Bar, "from-real-env")
+	}
+	// Earlier dotenv file wins over later ones.
+	if cli.Foo != "from-first" {
+		t.Errorf("Foo = %q, want %q", cli.Foo, "from-first")
+	}
+	// Falls through to a later file when an earlier one lacks the key.
+	if cli.Baz != "from-second" {
+		t.Errorf("Baz = %q, want %q", cli.Baz, "from-second")
+	}
+}
+
+func TestDotEnvResolvesPositionalArgs(t *testing.T) {
+	dir := t.TempDir()
+	path := writeDotEnv(t, dir, ".env", "NAME=world\n")
+
+	resolver, err := kong.DotEnv(path)
+	if err != nil {
+		t.Fatalf("DotEnv: %v", err)
+	}
+
+	// An optional positional, not a required one: kong's own
+	// missing-positional check (context.go's checkMissingPositionals)
+	// decides whether a *required* positional was supplied by consulting
+	// os.LookupEnv directly, never a Resolver, so a dotenv-only value can
+	// never satisfy it. See the DotEnv doc comment.
+	var cli struct {
+		Name string `arg:"" optional:"" env:"NAME"`
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(resolver))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if cli.Name != "world" {
+		t.Errorf("Name = %q, want %q", cli.Name, "world")
+	}
+}
+
+// applyCount is a custom mapper target that records how many times it was
+// parsed, via encoding.TextUnmarshaler, rather than the value it was parsed
+// to. It stands in for any non-idempotent mapper (a counter, an appending
+// slice) that would visibly misbehave if the same positional were resolved
+// more than once for a single parse.
+type applyCount int
+
+func (c *applyCount) UnmarshalText(_ []byte) error {
+	*c++
+	return nil
+}
+
+func TestEnvResolverAndDotEnvShareArgResolutionPerContext(t *testing.T) {
+	dir := t.TempDir()
+	// ONLY_DOTENV isn't set in the real environment at all, so EnvResolver's
+	// lookup can never fill it; DotEnv must still get a chance to.
+	path := writeDotEnv(t, dir, ".env", "IN_BOTH=from-dotenv\nONLY_DOTENV=from-dotenv\n")
+
+	dotenvResolver, err := kong.DotEnv(path)
+	if err != nil {
+		t.Fatalf("DotEnv: %v", err)
+	}
+
+	// Set a real environment variable too, so DotEnv's lookup (which checks
+	// os.LookupEnv before its files) resolves to the exact same value
+	// EnvResolver already applied, and the two resolvers race to parse the
+	// same positional.
+	os.Setenv("IN_BOTH", "from-real-env")
+	t.Cleanup(func() { os.Unsetenv("IN_BOTH") })
+
+	var cli struct {
+		InBoth     applyCount `arg:"" optional:"" env:"IN_BOTH"`
+		OnlyDotenv string     `arg:"" optional:"" env:"ONLY_DOTENV"`
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(kong.EnvResolver(), dotenvResolver))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	// The positional both resolvers can see must be parsed exactly once for
+	// the Context, no matter how many registered Resolvers would otherwise
+	// each resolve args on their first Resolve call. See resolveArgsOnce.
+	if cli.InBoth != 1 {
+		t.Errorf("InBoth applied %d times, want 1", cli.InBoth)
+	}
+	// The positional only DotEnv's lookup can see must still get filled:
+	// EnvResolver claiming the Context's arg-resolution gate first must not
+	// permanently lock DotEnv out of positionals EnvResolver's own lookup
+	// didn't have a value for.
+	if cli.OnlyDotenv != "from-dotenv" {
+		t.Errorf("OnlyDotenv = %q, want %q", cli.OnlyDotenv, "from-dotenv")
+	}
+}
@@ -0,0 +1,69 @@
+package kong
+
+import (
+	"io"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// TOML returns a Resolver that retrieves values from a TOML source.
+//
+// Flag names are used as TOML keys indirectly, by trying snake_case and camelCase variants,
+// and the same dotted-path descent as JSON. TOML tables map naturally onto dotted flag names,
+// so a `[server]` table with a `port` key resolves `--server.port`.
+//
+// Unlike JSON and YAML, TOML has native date and time types. An
+// offset-qualified datetime decodes to time.Time and is formatted as
+// RFC 3339; a bare date, time, or datetime with no offset (TOML's
+// "local" variants) decodes to toml.LocalDate, toml.LocalTime, or
+// toml.LocalDateTime instead, and is formatted via its own String method.
+// Either way the value reaching kong's mappers is a plain string, the same
+// as it would be from JSON or YAML.
+func TOML(r io.Reader) (Resolver, error) {
+	values := map[string]any{}
+	_, err := toml.NewDecoder(r).Decode(&values)
+	if err != nil {
+		return nil, err
+	}
+	return namedResolver{name: "toml", ResolverFunc: mapResolver(stringifyTOMLTimes(values).(map[string]any))}, nil
+}
+
+// stringifyTOMLTimes walks a decoded TOML value, replacing any time.Time,
+// toml.LocalDate, toml.LocalTime, or toml.LocalDateTime with its string
+// representation so downstream resolution sees plain strings, as it would
+// from JSON or YAML.
+func stringifyTOMLTimes(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case toml.LocalDate:
+		return v.String()
+	case toml.LocalTime:
+		return v.String()
+	case toml.LocalDateTime:
+		return v.String()
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, vv := range v {
+			out[k] = stringifyTOMLTimes(vv)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, vv := range v {
+			out[i] = stringifyTOMLTimes(vv)
+		}
+		return out
+	case []map[string]any:
+		// Array-of-tables ([[section]]) decodes to this concrete type
+		// rather than []any, so it needs its own case to be walked.
+		out := make([]map[string]any, len(v))
+		for i, vv := range v {
+			out[i] = stringifyTOMLTimes(vv).(map[string]any)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,144 @@
+package kong_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kong"
+)
+
+func TestTOMLResolver(t *testing.T) {
+	tomlConfig := `
+string = "hello"
+number = 42
+flag = true
+list = ["a", "b", "c"]
+
+[server]
+port = 8080
+`
+	resolver, err := kong.TOML(strings.NewReader(tomlConfig))
+	if err != nil {
+		t.Fatalf("TOML: %v", err)
+	}
+
+	var cli struct {
+		String string
+		Number int
+		Flag   bool
+		List   []string
+		Server struct {
+			Port int
+		} `embed:"" prefix:"server."`
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(resolver))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if cli.String != "hello" {
+		t.Errorf("String = %q, want %q", cli.String, "hello")
+	}
+	if cli.Number != 42 {
+		t.Errorf("Number = %d, want %d", cli.Number, 42)
+	}
+	if !cli.Flag {
+		t.Errorf("Flag = %v, want %v", cli.Flag, true)
+	}
+	if cli.Server.Port != 8080 {
+		t.Errorf("Server.Port = %d, want %d", cli.Server.Port, 8080)
+	}
+}
+
+func TestTOMLResolverStringifiesDatetimes(t *testing.T) {
+	tomlConfig := `started = 2024-01-01T00:00:00Z`
+
+	resolver, err := kong.TOML(strings.NewReader(tomlConfig))
+	if err != nil {
+		t.Fatalf("TOML: %v", err)
+	}
+
+	var cli struct {
+		Started string
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(resolver))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if want := "2024-01-01T00:00:00Z"; cli.Started != want {
+		t.Errorf("Started = %q, want %q", cli.Started, want)
+	}
+}
+
+func TestTOMLResolverStringifiesLocalDatetimes(t *testing.T) {
+	// Bare (offset-less) TOML dates and datetimes decode to BurntSushi's own
+	// toml.LocalDate / toml.LocalDateTime, not time.Time, so they need their
+	// own stringifyTOMLTimes cases.
+	tomlConfig := `
+day = 2024-01-01
+started = 2024-01-01T00:00:00
+`
+	resolver, err := kong.TOML(strings.NewReader(tomlConfig))
+	if err != nil {
+		t.Fatalf("TOML: %v", err)
+	}
+
+	var cli struct {
+		Day     string
+		Started string
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(resolver))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if want := "2024-01-01"; cli.Day != want {
+		t.Errorf("Day = %q, want %q", cli.Day, want)
+	}
+	if want := "2024-01-01T00:00:00"; cli.Started != want {
+		t.Errorf("Started = %q, want %q", cli.Started, want)
+	}
+}
+
+func TestTOMLResolverStringifiesDatetimesInArrayOfTables(t *testing.T) {
+	tomlConfig := `
+[[server]]
+name = "a"
+started = 2024-01-01T00:00:00Z
+
+[[server]]
+name = "b"
+started = 2024-01-02T00:00:00Z
+`
+	resolver, err := kong.TOML(strings.NewReader(tomlConfig))
+	if err != nil {
+		t.Fatalf("TOML: %v", err)
+	}
+
+	// Array-of-tables decodes to []map[string]any, a distinct concrete type
+	// from []any, so it needs its own resolution path to reach here.
+	value, err := resolver.Resolve(nil, nil, &kong.Flag{Value: &kong.Value{Name: "server"}})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	servers, ok := value.([]map[string]any)
+	if !ok {
+		t.Fatalf("value = %#v, want []map[string]any", value)
+	}
+	want := []string{"2024-01-01T00:00:00Z", "2024-01-02T00:00:00Z"}
+	for i, w := range want {
+		if got := servers[i]["started"]; got != w {
+			t.Errorf("server[%d].started = %v, want %q", i, got, w)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package kong
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML returns a Resolver that retrieves values from a YAML source.
+//
+// Flag names are used as YAML keys indirectly, by trying snake_case and camelCase variants,
+// and the same dotted-path descent as JSON.
+func YAML(r io.Reader) (Resolver, error) {
+	values := map[string]any{}
+	err := yaml.NewDecoder(r).Decode(&values)
+	if err != nil {
+		return nil, err
+	}
+	return namedResolver{name: "yaml", ResolverFunc: mapResolver(values)}, nil
+}
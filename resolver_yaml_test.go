@@ -0,0 +1,69 @@
+package kong_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kong"
+)
+
+func TestYAMLResolver(t *testing.T) {
+	yamlConfig := `
+string: hello
+number: 42
+flag: true
+list:
+  - a
+  - b
+  - c
+nested:
+  value: world
+`
+	resolver, err := kong.YAML(strings.NewReader(yamlConfig))
+	if err != nil {
+		t.Fatalf("YAML: %v", err)
+	}
+
+	var cli struct {
+		String      string
+		Number      int
+		Flag        bool
+		List        []string
+		NestedValue string `name:"nested.value"`
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(resolver))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if cli.String != "hello" {
+		t.Errorf("String = %q, want %q", cli.String, "hello")
+	}
+	if cli.Number != 42 {
+		t.Errorf("Number = %d, want %d", cli.Number, 42)
+	}
+	if !cli.Flag {
+		t.Errorf("Flag = %v, want %v", cli.Flag, true)
+	}
+	if want := []string{"a", "b", "c"}; !equalStrings(cli.List, want) {
+		t.Errorf("List = %v, want %v", cli.List, want)
+	}
+	if cli.NestedValue != "world" {
+		t.Errorf("NestedValue = %q, want %q", cli.NestedValue, "world")
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,185 @@
+// Package aws provides kong Resolvers backed by AWS Parameter Store and
+// Secrets Manager, kept in a subpackage so the AWS SDK dependency stays
+// optional for consumers who don't need it.
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/kong"
+)
+
+// SSMClient is the subset of the AWS SSM API used by SSMResolver.
+type SSMClient interface {
+	GetParameter(ctx context.Context, path string) (string, error)
+}
+
+// SecretsManagerClient is the subset of the AWS Secrets Manager API used by SecretsManagerResolver.
+type SecretsManagerClient interface {
+	GetSecretValue(ctx context.Context, id string) (string, error)
+}
+
+// SSMOption configures an SSMResolver or SecretsManagerResolver.
+type SSMOption func(*resolver)
+
+// WithContext sets the context.Context used for remote calls. Defaults to context.Background().
+func WithContext(ctx context.Context) SSMOption {
+	return func(r *resolver) { r.ctx = ctx }
+}
+
+type fetcher interface {
+	fetch(ctx context.Context, path string) (string, error)
+}
+
+type ssmFetcher struct{ client SSMClient }
+
+func (f ssmFetcher) fetch(ctx context.Context, path string) (string, error) {
+	return f.client.GetParameter(ctx, path)
+}
+
+type secretsManagerFetcher struct{ client SecretsManagerClient }
+
+func (f secretsManagerFetcher) fetch(ctx context.Context, id string) (string, error) {
+	return f.client.GetSecretValue(ctx, id)
+}
+
+// resolver looks up flag values from a remote secret store, identified by
+// the struct tag named in tagName (e.g. "ssm" or "secret"). Values are
+// fetched lazily on first Resolve and cached per Context so a secret
+// referenced by multiple flags is only fetched once per parse.
+type resolver struct {
+	tagName string
+	fetch   fetcher
+	ctx     context.Context
+
+	mu sync.Mutex
+	// cache is keyed by kong.Context.Key rather than *kong.Context itself:
+	// the latter would be a strong reference held by this struct for as
+	// long as the resolver lives, pinning every Context ever seen and
+	// defeating the OnDone eviction in fetchCached.
+	cache map[uintptr]map[string]string
+}
+
+// SSMResolver returns a Resolver that fetches values from AWS Systems
+// Manager Parameter Store for flags tagged with `ssm:"/path/to/param"`.
+//
+// A path may optionally be suffixed with "#field" to select a single field
+// out of a JSON-encoded parameter value, e.g. `ssm:"/myapp/prod/db#password"`.
+func SSMResolver(client SSMClient, opts ...SSMOption) kong.Resolver {
+	return newResolver("ssm", ssmFetcher{client}, opts)
+}
+
+// SecretsManagerResolver returns a Resolver that fetches values from AWS
+// Secrets Manager for flags tagged with `secret:"myapp/prod/db"`.
+//
+// A secret ID may optionally be suffixed with "#field" to select a single
+// field out of a JSON-encoded secret bundle, e.g. `secret:"myapp/prod/db#password"`.
+func SecretsManagerResolver(client SecretsManagerClient, opts ...SSMOption) kong.Resolver {
+	return newResolver("secret", secretsManagerFetcher{client}, opts)
+}
+
+func newResolver(tagName string, fetch fetcher, opts []SSMOption) *resolver {
+	r := &resolver{
+		tagName: tagName,
+		fetch:   fetch,
+		ctx:     context.Background(),
+		cache:   map[uintptr]map[string]string{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Validate implements kong.Resolver. It rejects flags whose tag value is
+// empty or resolves to an empty path once the "#field" selector is
+// stripped, across the whole command tree, not just the flags on the
+// node being invoked.
+func (r *resolver) Validate(app *kong.Application) error {
+	return r.validateNode(app.Node)
+}
+
+func (r *resolver) validateNode(node *kong.Node) error {
+	for _, flag := range node.Flags {
+		if !flag.Tag.Has(r.tagName) {
+			continue
+		}
+		path, _, _ := strings.Cut(flag.Tag.Get(r.tagName), "#")
+		if strings.TrimSpace(path) == "" {
+			return fmt.Errorf("%s: %s tag has an empty path", flag.Name, r.tagName)
+		}
+	}
+	for _, child := range node.Children {
+		if err := r.validateNode(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Resolve implements kong.Resolver.
+func (r *resolver) Resolve(context *kong.Context, parent *kong.Path, flag *kong.Flag) (any, error) {
+	if !flag.Tag.Has(r.tagName) {
+		return nil, nil
+	}
+	path, field, hasField := strings.Cut(flag.Tag.Get(r.tagName), "#")
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("%s: %s tag has an empty path", flag.Name, r.tagName)
+	}
+
+	raw, err := r.fetchCached(context, path)
+	if err != nil {
+		return nil, fmt.Errorf("%s %q: %w", r.tagName, path, err)
+	}
+	if !hasField {
+		return raw, nil
+	}
+
+	bundle := map[string]any{}
+	if err := json.Unmarshal([]byte(raw), &bundle); err != nil {
+		return nil, fmt.Errorf("%s %q: decoding JSON bundle for field %q: %w", r.tagName, path, field, err)
+	}
+	value, ok := bundle[field]
+	if !ok {
+		return nil, fmt.Errorf("%s %q: field %q not found in secret bundle", r.tagName, path, field)
+	}
+	return value, nil
+}
+
+func (r *resolver) fetchCached(kctx *kong.Context, path string) (string, error) {
+	key := kctx.Key()
+	r.mu.Lock()
+	values, ok := r.cache[key]
+	if !ok {
+		values = map[string]string{}
+		r.cache[key] = values
+		// Evict this cache entry once the Context is garbage collected.
+		// See kong.Context.OnDone's doc comment for why OnDone, not a
+		// bare runtime.SetFinalizer, is what makes this safe to do
+		// alongside kong.Chain's own per-Context eviction.
+		kctx.OnDone(func() {
+			r.mu.Lock()
+			delete(r.cache, key)
+			r.mu.Unlock()
+		})
+	}
+	if value, ok := values[path]; ok {
+		r.mu.Unlock()
+		return value, nil
+	}
+	r.mu.Unlock()
+
+	value, err := r.fetch.fetch(r.ctx, path)
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	values[path] = value
+	r.mu.Unlock()
+	return value, nil
+}
@@ -0,0 +1,162 @@
+package aws_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/alecthomas/kong/resolvers/aws"
+)
+
+type fakeSSMClient struct {
+	calls  int
+	values map[string]string
+}
+
+func (f *fakeSSMClient) GetParameter(ctx context.Context, path string) (string, error) {
+	f.calls++
+	value, ok := f.values[path]
+	if !ok {
+		return "", fmt.Errorf("no such parameter: %s", path)
+	}
+	return value, nil
+}
+
+func TestSSMResolverFetchesAndCachesPerContext(t *testing.T) {
+	client := &fakeSSMClient{values: map[string]string{
+		"/myapp/prod/password": "hunter2",
+	}}
+	resolver := aws.SSMResolver(client)
+
+	var cli struct {
+		Password string `ssm:"/myapp/prod/password"`
+		Other    string `ssm:"/myapp/prod/password"`
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(resolver))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if cli.Password != "hunter2" || cli.Other != "hunter2" {
+		t.Errorf("Password = %q, Other = %q, want both %q", cli.Password, cli.Other, "hunter2")
+	}
+	if client.calls != 1 {
+		t.Errorf("client.calls = %d, want 1 (result should be cached within a single parse)", client.calls)
+	}
+}
+
+func TestSSMResolverSelectsFieldFromJSONBundle(t *testing.T) {
+	client := &fakeSSMClient{values: map[string]string{
+		"/myapp/prod/db": `{"username": "admin", "password": "hunter2"}`,
+	}}
+	resolver := aws.SSMResolver(client)
+
+	var cli struct {
+		Password string `ssm:"/myapp/prod/db#password"`
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(resolver))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if cli.Password != "hunter2" {
+		t.Errorf("Password = %q, want %q", cli.Password, "hunter2")
+	}
+}
+
+func TestSSMResolverValidateRejectsEmptyPath(t *testing.T) {
+	resolver := aws.SSMResolver(&fakeSSMClient{})
+
+	var cli struct {
+		Password string `ssm:""`
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(resolver))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err == nil {
+		t.Errorf("expected Validate to reject an empty ssm path")
+	}
+}
+
+type fakeSecretsManagerClient struct {
+	calls  int
+	values map[string]string
+}
+
+func (f *fakeSecretsManagerClient) GetSecretValue(ctx context.Context, id string) (string, error) {
+	f.calls++
+	value, ok := f.values[id]
+	if !ok {
+		return "", fmt.Errorf("no such secret: %s", id)
+	}
+	return value, nil
+}
+
+func TestSecretsManagerResolverFetchesAndCachesPerContext(t *testing.T) {
+	client := &fakeSecretsManagerClient{values: map[string]string{
+		"myapp/prod/password": "hunter2",
+	}}
+	resolver := aws.SecretsManagerResolver(client)
+
+	var cli struct {
+		Password string `secret:"myapp/prod/password"`
+		Other    string `secret:"myapp/prod/password"`
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(resolver))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if cli.Password != "hunter2" || cli.Other != "hunter2" {
+		t.Errorf("Password = %q, Other = %q, want both %q", cli.Password, cli.Other, "hunter2")
+	}
+	if client.calls != 1 {
+		t.Errorf("client.calls = %d, want 1 (result should be cached within a single parse)", client.calls)
+	}
+}
+
+func TestSecretsManagerResolverSelectsFieldFromJSONBundle(t *testing.T) {
+	client := &fakeSecretsManagerClient{values: map[string]string{
+		"myapp/prod/db": `{"username": "admin", "password": "hunter2"}`,
+	}}
+	resolver := aws.SecretsManagerResolver(client)
+
+	var cli struct {
+		Password string `secret:"myapp/prod/db#password"`
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(resolver))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if cli.Password != "hunter2" {
+		t.Errorf("Password = %q, want %q", cli.Password, "hunter2")
+	}
+}
+
+func TestSecretsManagerResolverValidateRejectsEmptyPath(t *testing.T) {
+	resolver := aws.SecretsManagerResolver(&fakeSecretsManagerClient{})
+
+	var cli struct {
+		Password string `secret:""`
+	}
+	parser, err := kong.New(&cli, kong.Resolvers(resolver))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	if _, err := parser.Parse(nil); err == nil {
+		t.Errorf("expected Validate to reject an empty secret path")
+	}
+}